@@ -0,0 +1,351 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// RetryPolicy controls the backoff used by LogAsync when a batch send
+// fails with a transient error (5xx, network error, or 429).
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryPolicy is used by LogAsync when WithRetry is not supplied.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+}
+
+// WithAsyncBatch enables LogAsync and configures how queued events are
+// grouped before being flushed to the collector. A batch is sent once it
+// reaches maxSize events or maxWait elapses since the batch was opened,
+// whichever comes first.
+func WithAsyncBatch(maxSize int, maxWait time.Duration) ClientOption {
+	return func(c *Client) {
+		c.asyncMaxSize = maxSize
+		c.asyncMaxWait = maxWait
+		c.asyncEnabled = true
+	}
+}
+
+// WithRetry overrides DefaultRetryPolicy for LogAsync batch sends.
+func WithRetry(policy RetryPolicy) ClientOption {
+	return func(c *Client) { c.retryPolicy = policy }
+}
+
+// WithSpoolDir backs the LogAsync queue with an on-disk write-ahead log
+// under dir, so events survive a process crash and are re-ingested the
+// next time a Client is constructed against the same spool dir. maxBytes
+// rotates the active segment once exceeded; 0 disables rotation.
+func WithSpoolDir(dir string, maxBytes int64) ClientOption {
+	return func(c *Client) {
+		c.spoolDir = dir
+		c.spoolMaxBytes = maxBytes
+	}
+}
+
+// WithOnError registers a callback invoked when LogAsync drops an event:
+// either the collector rejected it outright (4xx) or the retry policy was
+// exhausted on a transient error.
+func WithOnError(fn func(event AuditEvent, err error)) ClientOption {
+	return func(c *Client) { c.onAsyncError = fn }
+}
+
+// asyncBatcher runs the background worker behind LogAsync. It batches
+// events by size and time, retries transient send failures with
+// exponential backoff and jitter, and optionally spools unsent events to
+// disk so they survive a crash.
+type asyncBatcher struct {
+	c   *Client
+	wal *spoolWAL
+
+	// enqueueMu serializes LogAsync callers across the capacity check,
+	// the WAL append, and the queueCh send below, so that a) the queue
+	// isn't wrongly reported full because of a race with another
+	// producer's send, and b) events always reach the WAL in the same
+	// order they reach queueCh, even with concurrent callers — send's
+	// Ack accounting depends on that order matching.
+	enqueueMu sync.Mutex
+
+	queueCh chan AuditEvent
+	flushCh chan chan struct{}
+	stopCh  chan struct{}
+	doneCh  chan struct{}
+}
+
+func startAsyncBatcher(c *Client) (*asyncBatcher, error) {
+	b := &asyncBatcher{
+		c:       c,
+		queueCh: make(chan AuditEvent, c.asyncMaxSize*4),
+		flushCh: make(chan chan struct{}),
+		stopCh:  make(chan struct{}),
+		doneCh:  make(chan struct{}),
+	}
+
+	var pending []AuditEvent
+	if c.spoolDir != "" {
+		wal, err := openSpoolWAL(c.spoolDir, c.spoolMaxBytes)
+		if err != nil {
+			return nil, fmt.Errorf("open spool dir %s: %w", c.spoolDir, err)
+		}
+		pending, err = wal.Recover()
+		if err != nil {
+			return nil, fmt.Errorf("recover spool dir %s: %w", c.spoolDir, err)
+		}
+		b.wal = wal
+	}
+
+	go b.run(pending)
+	return b, nil
+}
+
+// LogAsync enqueues event for batched delivery. It returns once the event
+// has been validated and (if a spool dir is configured) durably written to
+// disk; it does not wait for the HTTP round trip. The internal queue is
+// bounded (asyncMaxSize*4 events), and LogAsync does not block waiting for
+// room in it: if the queue is full — e.g. the batcher is stuck retrying a
+// previous send — LogAsync returns an error immediately instead of hanging
+// the caller.
+// LogAsync requires WithAsyncBatch to have been set on the Client.
+func (c *Client) LogAsync(event AuditEvent) error {
+	if c.batcherErr != nil {
+		return fmt.Errorf("async batcher unavailable: %w", c.batcherErr)
+	}
+	if !c.asyncEnabled || c.batcher == nil {
+		return fmt.Errorf("LogAsync requires WithAsyncBatch to be configured on the client")
+	}
+
+	c.fillDefaults(&event)
+	if err := c.validator.Validate(&event); err != nil {
+		return fmt.Errorf("validate event: %w", err)
+	}
+	if err := c.sign(&event); err != nil {
+		return fmt.Errorf("sign event: %w", err)
+	}
+
+	b := c.batcher
+	b.enqueueMu.Lock()
+	defer b.enqueueMu.Unlock()
+
+	select {
+	case <-b.stopCh:
+		return fmt.Errorf("client is closed")
+	default:
+	}
+	if len(b.queueCh) >= cap(b.queueCh) {
+		return fmt.Errorf("LogAsync queue is full (capacity %d); the batcher may be stalled retrying a previous send", cap(b.queueCh))
+	}
+
+	// From here on the send below is guaranteed not to block: we just
+	// checked there's room, and enqueueMu keeps every other producer
+	// from taking that room before we do. That lets us append to the
+	// WAL first (so the event is durable before LogAsync returns) without
+	// risking an orphan entry that never reaches queueCh — unlike a bare
+	// select with a default case, nothing here can fail after the append.
+	if b.wal != nil {
+		if err := b.wal.Append(event); err != nil {
+			return fmt.Errorf("spool event: %w", err)
+		}
+	}
+	b.queueCh <- event
+	return nil
+}
+
+// Flush blocks until every event queued by LogAsync so far has been sent
+// (or handed to OnError), or ctx is done. Flush is a no-op if LogAsync was
+// never configured.
+func (c *Client) Flush(ctx context.Context) error {
+	if !c.asyncEnabled || c.batcher == nil {
+		return nil
+	}
+
+	done := make(chan struct{})
+	select {
+	case c.batcher.flushCh <- done:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.batcher.stopCh:
+		return fmt.Errorf("client is closed")
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close flushes any events queued by LogAsync and stops its worker. It is
+// safe to call Close on a Client that never had WithAsyncBatch configured.
+func (c *Client) Close(ctx context.Context) error {
+	if !c.asyncEnabled || c.batcher == nil {
+		return nil
+	}
+	if err := c.Flush(ctx); err != nil {
+		return err
+	}
+
+	close(c.batcher.stopCh)
+	select {
+	case <-c.batcher.doneCh:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if c.batcher.wal != nil {
+		return c.batcher.wal.Close()
+	}
+	return nil
+}
+
+func (b *asyncBatcher) run(pending []AuditEvent) {
+	defer close(b.doneCh)
+
+	batch := append([]AuditEvent(nil), pending...)
+	timer := time.NewTimer(b.c.asyncMaxWait)
+	defer timer.Stop()
+
+	flush := func(waiters []chan struct{}) {
+		if len(batch) > 0 {
+			b.send(batch)
+			batch = nil
+		}
+		for _, w := range waiters {
+			close(w)
+		}
+	}
+
+	var waiters []chan struct{}
+	for {
+		select {
+		case e := <-b.queueCh:
+			batch = append(batch, e)
+			if len(batch) >= b.c.asyncMaxSize {
+				flush(waiters)
+				waiters = nil
+				timer.Reset(b.c.asyncMaxWait)
+			}
+
+		case <-timer.C:
+			flush(waiters)
+			waiters = nil
+			timer.Reset(b.c.asyncMaxWait)
+
+		case done := <-b.flushCh:
+			waiters = append(waiters, done)
+			// Pick up everything LogAsync already queued, so the flush
+			// covers every event enqueued before Flush was called.
+			for drained := false; !drained; {
+				select {
+				case e := <-b.queueCh:
+					batch = append(batch, e)
+				default:
+					drained = true
+				}
+			}
+			flush(waiters)
+			waiters = nil
+			timer.Reset(b.c.asyncMaxWait)
+
+		case <-b.stopCh:
+			for {
+				select {
+				case e := <-b.queueCh:
+					batch = append(batch, e)
+				default:
+					flush(waiters)
+					return
+				}
+			}
+		}
+	}
+}
+
+// send delivers batch to the collector, retrying transient failures with
+// exponential backoff and jitter. Once the retry policy is exhausted (or
+// the failure is a hard 4xx), every event in the batch is handed to
+// OnError, if configured.
+//
+// This calls the transport directly rather than Client.LogBatch: by the
+// time a batch reaches here, LogAsync has already run fillDefaults,
+// Validate, and sign on every event. Routing back through LogBatch would
+// run all three again on each attempt, corrupting the signer's prev_hash
+// chain (and, after a spool replay, doing it a second time on top of
+// that) and re-validating events that already passed.
+//
+// Either way the loop below ends, batch is done: delivered, or handed off
+// to OnError. If a spool dir is configured, it's acked so the WAL can
+// compact it away instead of redelivering it on the next restart.
+func (b *asyncBatcher) send(batch []AuditEvent) {
+	policy := b.c.retryPolicy
+
+	var lastErr error
+	for attempt := 0; attempt < policy.MaxAttempts; attempt++ {
+		if _, err := b.c.transport.Send(context.Background(), batch); err == nil {
+			b.ack(len(batch))
+			return
+		} else {
+			lastErr = err
+			if !isRetryable(err) {
+				break
+			}
+		}
+		time.Sleep(backoffDelay(policy, attempt))
+	}
+
+	if b.c.onAsyncError != nil {
+		for _, e := range batch {
+			b.c.onAsyncError(e, lastErr)
+		}
+	}
+	b.ack(len(batch))
+}
+
+// ack tells the spool WAL (if any) that the oldest n events are done with.
+// A failure here only means stale data is left on disk to be redelivered
+// next restart, not that delivery itself failed, so it's a best-effort
+// call: there's nowhere to surface a background compaction error that
+// Close/Flush callers would be watching for.
+func (b *asyncBatcher) ack(n int) {
+	if b.wal == nil {
+		return
+	}
+	b.wal.Ack(n)
+}
+
+func isRetryable(err error) bool {
+	var cerr *CollectorError
+	if errors.As(err, &cerr) {
+		return cerr.StatusCode == 429 || cerr.StatusCode >= 500
+	}
+	// Anything that isn't a collector response (timeouts, connection
+	// refused, DNS failures, ...) is treated as transient.
+	return true
+}
+
+func backoffDelay(policy RetryPolicy, attempt int) time.Duration {
+	base := policy.BaseDelay
+	if base <= 0 {
+		base = DefaultRetryPolicy.BaseDelay
+	}
+	max := policy.MaxDelay
+	if max <= 0 {
+		max = DefaultRetryPolicy.MaxDelay
+	}
+
+	d := time.Duration(float64(base) * math.Pow(2, float64(attempt)))
+	if d > max {
+		d = max
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d)/2+1))
+}
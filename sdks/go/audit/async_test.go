@@ -0,0 +1,208 @@
+package audit
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flushUntil repeatedly calls c.Flush until done reports true or the
+// one-second deadline passes, failing the test in the latter case.
+func flushUntil(t *testing.T, c *Client, done func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !done() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the async batcher to flush")
+		}
+		if err := c.Flush(context.Background()); err != nil {
+			t.Fatalf("Flush: %v", err)
+		}
+	}
+}
+
+func TestLogAsync_FlushSendsBatchThroughTransport(t *testing.T) {
+	ft := &fakeTransport{}
+	c := NewClient("", WithTransport(ft), WithAsyncBatch(10, time.Hour))
+
+	if err := c.LogAsync(AuditEvent{Action: ActionContext{Type: "READ", Category: "DATABASE", Operation: "op1"}}); err != nil {
+		t.Fatalf("LogAsync: %v", err)
+	}
+	if err := c.LogAsync(AuditEvent{Action: ActionContext{Type: "READ", Category: "DATABASE", Operation: "op2"}}); err != nil {
+		t.Fatalf("LogAsync: %v", err)
+	}
+
+	flushUntil(t, c, func() bool { return ft.sentCount() == 2 })
+}
+
+func TestLogAsync_BatchFlushesAtMaxSize(t *testing.T) {
+	ft := &fakeTransport{}
+	c := NewClient("", WithTransport(ft), WithAsyncBatch(2, time.Hour))
+
+	for i := 0; i < 2; i++ {
+		if err := c.LogAsync(AuditEvent{Action: ActionContext{Type: "READ", Category: "DATABASE", Operation: "op"}}); err != nil {
+			t.Fatalf("LogAsync: %v", err)
+		}
+	}
+
+	deadline := time.After(time.Second)
+	for {
+		if ft.sentCount() == 2 {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("expected batch to auto-flush at maxSize, got %d events sent", ft.sentCount())
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func TestLogAsync_RetriesTransientErrorsThenSucceeds(t *testing.T) {
+	ct := &countingTransport{fn: func(attempt int, events []AuditEvent) (*BatchResponse, error) {
+		if attempt < 3 {
+			return nil, &CollectorError{StatusCode: 503}
+		}
+		return &BatchResponse{Status: "ok", Accepted: len(events)}, nil
+	}}
+	c := NewClient("", WithTransport(ct), WithAsyncBatch(10, time.Hour), WithRetry(RetryPolicy{
+		MaxAttempts: 5, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond,
+	}))
+
+	if err := c.LogAsync(AuditEvent{Action: ActionContext{Type: "READ", Category: "DATABASE", Operation: "op"}}); err != nil {
+		t.Fatalf("LogAsync: %v", err)
+	}
+	flushUntil(t, c, func() bool { return ct.callCount() >= 3 })
+	if n := ct.callCount(); n != 3 {
+		t.Errorf("expected 3 attempts before success, got %d", n)
+	}
+}
+
+func TestLogAsync_OnErrorCalledAfterRetriesExhausted(t *testing.T) {
+	ct := &countingTransport{fn: func(attempt int, events []AuditEvent) (*BatchResponse, error) {
+		return nil, &CollectorError{StatusCode: 503}
+	}}
+	var mu sync.Mutex
+	var dropped []AuditEvent
+	c := NewClient("", WithTransport(ct),
+		WithAsyncBatch(10, time.Hour),
+		WithRetry(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}),
+		WithOnError(func(e AuditEvent, err error) {
+			mu.Lock()
+			dropped = append(dropped, e)
+			mu.Unlock()
+		}),
+	)
+
+	if err := c.LogAsync(AuditEvent{Action: ActionContext{Type: "READ", Category: "DATABASE", Operation: "op"}}); err != nil {
+		t.Fatalf("LogAsync: %v", err)
+	}
+	flushUntil(t, c, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(dropped) == 1
+	})
+}
+
+func TestLogAsync_RequiresWithAsyncBatch(t *testing.T) {
+	c := NewClient("", WithTransport(&fakeTransport{}))
+	if err := c.LogAsync(AuditEvent{}); err == nil {
+		t.Fatal("expected an error without WithAsyncBatch configured")
+	}
+}
+
+func TestLogAsync_SpoolDirSurvivesClientRestart(t *testing.T) {
+	dir := t.TempDir()
+	ft := &fakeTransport{}
+
+	// asyncMaxWait is set very long so nothing auto-flushes; the first
+	// client is never told to Close or Flush, simulating a crash with an
+	// event still only on the spool.
+	c1 := NewClient("", WithTransport(ft), WithAsyncBatch(10, time.Hour), WithSpoolDir(filepath.Join(dir, "spool"), 0))
+	if err := c1.LogAsync(AuditEvent{EventID: "evt-1", Action: ActionContext{Type: "READ", Category: "DATABASE", Operation: "op"}}); err != nil {
+		t.Fatalf("LogAsync: %v", err)
+	}
+
+	ft2 := &fakeTransport{}
+	c2 := NewClient("", WithTransport(ft2), WithAsyncBatch(10, time.Hour), WithSpoolDir(filepath.Join(dir, "spool"), 0))
+	flushUntil(t, c2, func() bool { return ft2.sentCount() == 1 })
+
+	sent := ft2.sentEvents()
+	if len(sent) != 1 || sent[0].EventID != "evt-1" {
+		t.Fatalf("expected the spooled event to be re-ingested on restart, got %+v", sent)
+	}
+}
+
+func TestLogAsync_SpoolDoesNotRedeliverAfterSuccessfulSend(t *testing.T) {
+	dir := t.TempDir()
+	ft := &fakeTransport{}
+
+	c1 := NewClient("", WithTransport(ft), WithAsyncBatch(10, time.Hour), WithSpoolDir(filepath.Join(dir, "spool"), 0))
+	if err := c1.LogAsync(AuditEvent{EventID: "evt-1", Action: ActionContext{Type: "READ", Category: "DATABASE", Operation: "op"}}); err != nil {
+		t.Fatalf("LogAsync: %v", err)
+	}
+	flushUntil(t, c1, func() bool { return ft.sentCount() == 1 })
+
+	ft2 := &fakeTransport{}
+	c2 := NewClient("", WithTransport(ft2), WithAsyncBatch(10, time.Hour), WithSpoolDir(filepath.Join(dir, "spool"), 0))
+	// Nothing should be pending to redeliver: give the batcher a moment
+	// to flush whatever it recovered, then confirm it sent nothing.
+	if err := c2.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if n := ft2.sentCount(); n != 0 {
+		t.Errorf("expected the already-delivered event not to be resent after restart, got %d sent", n)
+	}
+}
+
+// TestLogAsync_QueueFullReturnsErrorInsteadOfBlocking exercises the full-queue
+// branch added to LogAsync: once queueCh's capacity (asyncMaxSize*4) is used
+// up, further calls must return an error immediately rather than hang the
+// caller waiting for room the stuck batcher isn't going to free up.
+func TestLogAsync_QueueFullReturnsErrorInsteadOfBlocking(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+	ct := &countingTransport{fn: func(attempt int, events []AuditEvent) (*BatchResponse, error) {
+		<-block
+		return &BatchResponse{Status: "ok", Accepted: len(events)}, nil
+	}}
+	c := NewClient("", WithTransport(ct), WithAsyncBatch(1, time.Hour))
+
+	var lastErr error
+	for i := 0; i < 10; i++ {
+		lastErr = c.LogAsync(AuditEvent{Action: ActionContext{Type: "READ", Category: "DATABASE", Operation: "op"}})
+		if lastErr != nil {
+			break
+		}
+	}
+	if lastErr == nil {
+		t.Fatal("expected LogAsync to report the queue full instead of blocking forever")
+	}
+}
+
+// countingTransport is a Transport backed by an arbitrary function, for
+// tests that need to vary the response across calls (e.g. fail then
+// succeed, to exercise retry behavior) or count how many times Send ran.
+type countingTransport struct {
+	fn func(attempt int, events []AuditEvent) (*BatchResponse, error)
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (t *countingTransport) Send(ctx context.Context, events []AuditEvent) (*BatchResponse, error) {
+	t.mu.Lock()
+	t.calls++
+	attempt := t.calls
+	t.mu.Unlock()
+	return t.fn(attempt, events)
+}
+
+func (t *countingTransport) callCount() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.calls
+}
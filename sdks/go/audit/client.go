@@ -1,12 +1,11 @@
 package audit
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,6 +18,30 @@ type Client struct {
 	application string
 	environment string
 	httpClient  *http.Client
+	transport   Transport
+	validator   *Validator
+	redact      *redactor
+
+	// LogAsync configuration; see WithAsyncBatch, WithRetry, WithSpoolDir,
+	// WithOnError.
+	asyncEnabled  bool
+	asyncMaxSize  int
+	asyncMaxWait  time.Duration
+	retryPolicy   RetryPolicy
+	spoolDir      string
+	spoolMaxBytes int64
+	onAsyncError  func(event AuditEvent, err error)
+
+	batcher    *asyncBatcher
+	batcherErr error
+
+	// ctxExtractor is set by WithContextExtractor; see applyContext.
+	ctxExtractor func(ctx context.Context) *SessionContext
+
+	// signer and the hash chain it maintains; see WithSigner and sign.go.
+	signer      Signer
+	signMu      sync.Mutex
+	prevSigHash []byte
 }
 
 // ClientOption configures a Client.
@@ -39,12 +62,31 @@ func WithEnvironment(env string) ClientOption {
 	return func(c *Client) { c.environment = env }
 }
 
-// WithTimeout sets the HTTP client timeout.
+// WithTimeout sets the HTTP client timeout. It has no effect once
+// WithTransport overrides the default HTTP transport.
 func WithTimeout(d time.Duration) ClientOption {
 	return func(c *Client) { c.httpClient.Timeout = d }
 }
 
-// NewClient creates a new audit SDK client.
+// WithContextExtractor lets applications auto-populate SessionContext
+// (session, request, and correlation IDs) from their own context keys —
+// an OpenTelemetry span, request-scoped auth, etc. — so LogContext and
+// LogBatchContext callers don't need to set Session by hand. It only
+// fills in Session fields that the event itself left unset.
+func WithContextExtractor(fn func(ctx context.Context) *SessionContext) ClientOption {
+	return func(c *Client) { c.ctxExtractor = fn }
+}
+
+// WithValidator overrides the Validator used to check events before they
+// are sent. Without this option the Client falls back to the
+// package-level ValidateEvent (the default Validator).
+func WithValidator(v *Validator) ClientOption {
+	return func(c *Client) { c.validator = v }
+}
+
+// NewClient creates a new audit SDK client that delivers events over HTTP
+// to collectorURL. Use WithTransport to deliver over a different wire
+// protocol instead; collectorURL is then ignored.
 func NewClient(collectorURL string, opts ...ClientOption) *Client {
 	c := &Client{
 		baseURL:    strings.TrimRight(collectorURL, "/"),
@@ -53,64 +95,93 @@ func NewClient(collectorURL string, opts ...ClientOption) *Client {
 	for _, opt := range opts {
 		opt(c)
 	}
+
+	if c.transport == nil {
+		c.transport = NewHTTPTransport(c.baseURL, c.apiKey, c.httpClient)
+	}
+	if c.validator == nil {
+		c.validator = defaultValidator
+	}
+
+	if c.asyncEnabled {
+		if c.asyncMaxSize <= 0 {
+			c.asyncMaxSize = 100
+		}
+		if c.asyncMaxWait <= 0 {
+			c.asyncMaxWait = 5 * time.Second
+		}
+		if c.retryPolicy == (RetryPolicy{}) {
+			c.retryPolicy = DefaultRetryPolicy
+		}
+		// A failure here (e.g. an unwritable spool dir) is deferred to the
+		// first LogAsync call rather than failing construction, so NewClient
+		// keeps its existing no-error signature.
+		c.batcher, c.batcherErr = startAsyncBatcher(c)
+	}
+
 	return c
 }
 
-// Log sends a single audit event. Fields are populated with defaults if empty.
-// The event is validated before sending; invalid events return an error without
-// making an HTTP call.
+// Log sends a single audit event using context.Background(). See
+// LogContext to propagate a caller's cancellation and deadlines.
 func (c *Client) Log(event AuditEvent) (*IngestResponse, error) {
+	return c.LogContext(context.Background(), event)
+}
+
+// LogContext sends a single audit event, building the request with ctx so
+// callers can propagate cancellation and deadlines from e.g. an inbound
+// request handler. Fields are populated with defaults if empty, and the
+// event is validated before sending; invalid events return an error
+// without making a Transport call.
+func (c *Client) LogContext(ctx context.Context, event AuditEvent) (*IngestResponse, error) {
 	c.fillDefaults(&event)
+	c.applyContext(ctx, &event)
 
-	if err := ValidateEvent(&event); err != nil {
+	if err := c.validator.Validate(&event); err != nil {
 		return nil, fmt.Errorf("validate event: %w", err)
 	}
-
-	body, err := json.Marshal(event)
-	if err != nil {
-		return nil, fmt.Errorf("marshal event: %w", err)
+	if err := c.sign(&event); err != nil {
+		return nil, fmt.Errorf("sign event: %w", err)
 	}
 
-	resp, err := c.doPost("/api/v1/events", body)
+	resp, err := c.transport.Send(ctx, []AuditEvent{event})
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	if len(resp.Errors) > 0 {
+		return nil, fmt.Errorf("collector rejected event: %s", resp.Errors[0].Error)
+	}
 
-	var result IngestResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
+	result := &IngestResponse{Status: resp.Status, EventID: event.EventID}
+	if len(resp.EventIDs) > 0 {
+		result.EventID = resp.EventIDs[0]
 	}
-	return &result, nil
+	return result, nil
 }
 
-// LogBatch sends multiple audit events in a single request (up to 100).
-// All events are validated before sending.
+// LogBatch sends multiple audit events in a single request (up to 100)
+// using context.Background(). See LogBatchContext to propagate a caller's
+// cancellation and deadlines.
 func (c *Client) LogBatch(events []AuditEvent) (*BatchResponse, error) {
+	return c.LogBatchContext(context.Background(), events)
+}
+
+// LogBatchContext sends multiple audit events in a single request (up to
+// 100), building the request with ctx so callers can propagate
+// cancellation and deadlines. All events are validated before sending.
+func (c *Client) LogBatchContext(ctx context.Context, events []AuditEvent) (*BatchResponse, error) {
 	for i := range events {
 		c.fillDefaults(&events[i])
-		if err := ValidateEvent(&events[i]); err != nil {
+		c.applyContext(ctx, &events[i])
+		if err := c.validator.Validate(&events[i]); err != nil {
 			return nil, fmt.Errorf("validate event[%d]: %w", i, err)
 		}
+		if err := c.sign(&events[i]); err != nil {
+			return nil, fmt.Errorf("sign event[%d]: %w", i, err)
+		}
 	}
 
-	batch := BatchRequest{Events: events}
-	body, err := json.Marshal(batch)
-	if err != nil {
-		return nil, fmt.Errorf("marshal batch: %w", err)
-	}
-
-	resp, err := c.doPost("/api/v1/events/batch", body)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var result BatchResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("decode response: %w", err)
-	}
-	return &result, nil
+	return c.transport.Send(ctx, events)
 }
 
 func (c *Client) fillDefaults(e *AuditEvent) {
@@ -136,30 +207,55 @@ func (c *Client) fillDefaults(e *AuditEvent) {
 			e.Metadata["environment"] = c.environment
 		}
 	}
+	if c.redact != nil {
+		c.redact.redact(e)
+	}
 }
 
-func (c *Client) doPost(path string, body []byte) (*http.Response, error) {
-	url := c.baseURL + path
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
-	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+// redactor lazily initializes and returns the Client's redactor, for use
+// by WithRedactKeys, WithRedactPatterns, WithRedactor, RedactCommonSecrets,
+// and RedactPII.
+func (c *Client) redactor() *redactor {
+	if c.redact == nil {
+		c.redact = &redactor{}
 	}
+	return c.redact
+}
 
-	req.Header.Set("Content-Type", "application/json")
-	if c.apiKey != "" {
-		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+// applyContext fills event.Session's SessionID, RequestID, and
+// CorrelationID from ctx where they are still unset, using the
+// WithContextExtractor callback if one is configured. It is a no-op
+// without WithContextExtractor.
+func (c *Client) applyContext(ctx context.Context, event *AuditEvent) {
+	if c.ctxExtractor == nil {
+		return
 	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("send request to %s: %w", url, err)
+	sc := c.ctxExtractor(ctx)
+	if sc == nil || *sc == (SessionContext{}) {
+		return
 	}
-
-	if resp.StatusCode >= 400 {
-		defer resp.Body.Close()
-		respBody, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("collector returned %d: %s", resp.StatusCode, string(respBody))
+	if event.Session == nil {
+		event.Session = &SessionContext{}
+	}
+	if event.Session.SessionID == "" {
+		event.Session.SessionID = sc.SessionID
 	}
+	if event.Session.RequestID == "" {
+		event.Session.RequestID = sc.RequestID
+	}
+	if event.Session.CorrelationID == "" {
+		event.Session.CorrelationID = sc.CorrelationID
+	}
+}
+
+// CollectorError is returned when the collector responds with a non-2xx
+// status. StatusCode lets callers distinguish transient failures (5xx,
+// 429) from hard validation failures (other 4xx) without parsing strings.
+type CollectorError struct {
+	StatusCode int
+	Body       string
+}
 
-	return resp, nil
+func (e *CollectorError) Error() string {
+	return fmt.Sprintf("collector returned %d: %s", e.StatusCode, e.Body)
 }
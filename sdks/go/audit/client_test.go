@@ -0,0 +1,145 @@
+package audit
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+// fakeTransport records every batch handed to Send and returns a
+// canned response, so client tests can assert on what reached the
+// transport without a real collector. Send can run on the async
+// batcher's own goroutine, so access to sent is guarded by mu.
+type fakeTransport struct {
+	mu   sync.Mutex
+	sent []AuditEvent
+	resp *BatchResponse
+	err  error
+}
+
+func (t *fakeTransport) Send(ctx context.Context, events []AuditEvent) (*BatchResponse, error) {
+	t.mu.Lock()
+	t.sent = append(t.sent, events...)
+	t.mu.Unlock()
+
+	if t.err != nil {
+		return nil, t.err
+	}
+	if t.resp != nil {
+		return t.resp, nil
+	}
+	ids := make([]string, len(events))
+	for i, e := range events {
+		ids[i] = e.EventID
+	}
+	return &BatchResponse{Status: "ok", Accepted: len(events), EventIDs: ids}, nil
+}
+
+func (t *fakeTransport) sentCount() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.sent)
+}
+
+func (t *fakeTransport) sentEvents() []AuditEvent {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]AuditEvent(nil), t.sent...)
+}
+
+func TestClient_LogContext_SendsThroughTransport(t *testing.T) {
+	ft := &fakeTransport{}
+	c := NewClient("", WithTransport(ft))
+
+	resp, err := c.LogContext(context.Background(), AuditEvent{
+		Action: ActionContext{Type: "READ", Category: "DATABASE", Operation: "query_users"},
+	})
+	if err != nil {
+		t.Fatalf("LogContext: %v", err)
+	}
+	if len(ft.sent) != 1 {
+		t.Fatalf("expected 1 event sent to transport, got %d", len(ft.sent))
+	}
+	if ft.sent[0].EventID == "" {
+		t.Error("expected fillDefaults to assign an event ID before sending")
+	}
+	if resp.EventID != ft.sent[0].EventID {
+		t.Errorf("expected response EventID %q to match sent event, got %q", ft.sent[0].EventID, resp.EventID)
+	}
+}
+
+func TestClient_LogContext_InvalidEventNeverReachesTransport(t *testing.T) {
+	ft := &fakeTransport{}
+	c := NewClient("", WithTransport(ft))
+
+	_, err := c.LogContext(context.Background(), AuditEvent{
+		Action: ActionContext{Type: "NOT_A_TYPE", Category: "DATABASE", Operation: "query_users"},
+	})
+	if err == nil {
+		t.Fatal("expected validation error for invalid action type")
+	}
+	if len(ft.sent) != 0 {
+		t.Fatalf("expected invalid event not to reach the transport, got %d events sent", len(ft.sent))
+	}
+}
+
+func TestClient_LogBatchContext_ValidatesEveryEvent(t *testing.T) {
+	ft := &fakeTransport{}
+	c := NewClient("", WithTransport(ft))
+
+	events := []AuditEvent{
+		{Action: ActionContext{Type: "READ", Category: "DATABASE", Operation: "op1"}},
+		{Action: ActionContext{Type: "BOGUS", Category: "DATABASE", Operation: "op2"}},
+	}
+	_, err := c.LogBatchContext(context.Background(), events)
+	if err == nil {
+		t.Fatal("expected an error from the second event's invalid action type")
+	}
+	if len(ft.sent) != 0 {
+		t.Fatalf("expected no events to reach the transport once one fails validation, got %d", len(ft.sent))
+	}
+}
+
+func TestClient_WithContextExtractor_FillsCorrelationID(t *testing.T) {
+	ft := &fakeTransport{}
+	type ctxKey struct{}
+	c := NewClient("", WithTransport(ft), WithContextExtractor(func(ctx context.Context) *SessionContext {
+		id, _ := ctx.Value(ctxKey{}).(string)
+		if id == "" {
+			return nil
+		}
+		return &SessionContext{CorrelationID: id}
+	}))
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "trace-123")
+	_, err := c.LogContext(ctx, AuditEvent{
+		Action: ActionContext{Type: "READ", Category: "DATABASE", Operation: "query_users"},
+	})
+	if err != nil {
+		t.Fatalf("LogContext: %v", err)
+	}
+	if len(ft.sent) != 1 {
+		t.Fatalf("expected 1 event sent, got %d", len(ft.sent))
+	}
+	if ft.sent[0].Session == nil || ft.sent[0].Session.CorrelationID != "trace-123" {
+		t.Errorf("expected correlation ID from context extractor, got %+v", ft.sent[0].Session)
+	}
+}
+
+func TestClient_WithContextExtractor_DoesNotOverrideExplicitCorrelationID(t *testing.T) {
+	ft := &fakeTransport{}
+	c := NewClient("", WithTransport(ft), WithContextExtractor(func(ctx context.Context) *SessionContext {
+		return &SessionContext{CorrelationID: "from-context"}
+	}))
+
+	_, err := c.LogContext(context.Background(), AuditEvent{
+		Action:  ActionContext{Type: "READ", Category: "DATABASE", Operation: "query_users"},
+		Session: &SessionContext{CorrelationID: "explicit"},
+	})
+	if err != nil {
+		t.Fatalf("LogContext: %v", err)
+	}
+	if ft.sent[0].Session.CorrelationID != "explicit" {
+		t.Errorf("expected explicit correlation ID to win, got %q", ft.sent[0].Session.CorrelationID)
+	}
+}
@@ -14,6 +14,16 @@ type AuditEvent struct {
 	System      map[string]interface{} `json:"system,omitempty"`
 	Custom      map[string]interface{} `json:"custom,omitempty"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Signature   *EventSignature        `json:"signature,omitempty"`
+}
+
+// EventSignature attaches a tamper-evident signature to an event. See
+// WithSigner and VerifyEvent in sign.go.
+type EventSignature struct {
+	Algorithm string `json:"alg"`
+	Signature string `json:"sig"`
+	KeyID     string `json:"key_id,omitempty"`
+	PrevHash  string `json:"prev_hash,omitempty"`
 }
 
 // SessionContext holds session identifiers.
@@ -0,0 +1,183 @@
+package audit
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// redactRule pairs a compiled regex with its replacement.
+type redactRule struct {
+	re          *regexp.Regexp
+	replacement string
+}
+
+// redactor masks sensitive Action.Parameters, Custom, Metadata, and Actor
+// fields before an event leaves the process, per the WithRedactKeys,
+// WithRedactPatterns, and WithRedactor rules registered on a Client.
+type redactor struct {
+	keyGlobs []string
+	rules    []redactRule
+	custom   []func(e *AuditEvent)
+}
+
+func (r *redactor) empty() bool {
+	return r == nil || (len(r.keyGlobs) == 0 && len(r.rules) == 0 && len(r.custom) == 0)
+}
+
+// WithRedactKeys masks any Action.Parameters, Custom, or Metadata entry
+// (and the Actor email/IP address, by name) whose key matches one of the
+// given glob patterns (path/filepath Match syntax, case-insensitive),
+// regardless of its value.
+func WithRedactKeys(keys ...string) ClientOption {
+	return func(c *Client) {
+		c.redactor().keyGlobs = append(c.redactor().keyGlobs, keys...)
+	}
+}
+
+// WithRedactPatterns masks any substring of a string value matching re,
+// replacing it with replacement. Use this for value-shaped secrets
+// (credit card numbers, JWTs, emails) that can show up regardless of
+// which field holds them.
+func WithRedactPatterns(re *regexp.Regexp, replacement string) ClientOption {
+	return func(c *Client) {
+		c.redactor().rules = append(c.redactor().rules, redactRule{re: re, replacement: replacement})
+	}
+}
+
+// WithRedactor registers arbitrary redaction logic that runs after the
+// key and pattern rules, with full access to the event.
+func WithRedactor(fn func(e *AuditEvent)) ClientOption {
+	return func(c *Client) {
+		c.redactor().custom = append(c.redactor().custom, fn)
+	}
+}
+
+// RedactCommonSecrets masks common secret formats (AWS access keys,
+// JWTs) and common secret-bearing key names (password, token, api_key,
+// ...) wherever they appear.
+func RedactCommonSecrets() ClientOption {
+	return func(c *Client) {
+		r := c.redactor()
+		r.rules = append(r.rules,
+			redactRule{re: awsAccessKeyPattern, replacement: "[REDACTED:aws_key]"},
+			redactRule{re: jwtPattern, replacement: "[REDACTED:jwt]"},
+		)
+		r.keyGlobs = append(r.keyGlobs, "password", "passwd", "secret", "*token*", "*api_key*", "*apikey*", "private_key")
+	}
+}
+
+// RedactPII masks common personally-identifiable formats (emails, US
+// social security numbers) and matching key names. Pass redactIPs=true
+// to also mask IPv4/IPv6 addresses, since some applications need to keep
+// real IPs for fraud/abuse analysis.
+func RedactPII(redactIPs bool) ClientOption {
+	return func(c *Client) {
+		r := c.redactor()
+		r.rules = append(r.rules,
+			redactRule{re: emailPattern, replacement: "[REDACTED:email]"},
+			redactRule{re: ssnPattern, replacement: "[REDACTED:ssn]"},
+		)
+		r.keyGlobs = append(r.keyGlobs, "email", "ssn", "social_security_number")
+		if redactIPs {
+			r.rules = append(r.rules,
+				redactRule{re: ipv4Pattern, replacement: "[REDACTED:ip]"},
+				redactRule{re: ipv6Pattern, replacement: "[REDACTED:ip]"},
+			)
+		}
+	}
+}
+
+var (
+	awsAccessKeyPattern = regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`)
+	jwtPattern          = regexp.MustCompile(`\b[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\b`)
+	emailPattern        = regexp.MustCompile(`\b[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}\b`)
+	ssnPattern          = regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`)
+	ipv4Pattern         = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+	ipv6Pattern         = regexp.MustCompile(`\b(?:[0-9A-Fa-f]{1,4}:){7}[0-9A-Fa-f]{1,4}\b`)
+)
+
+// redact masks e in place according to the configured rules.
+func (r *redactor) redact(e *AuditEvent) {
+	if r.empty() {
+		return
+	}
+	r.redactMap(e.Action.Parameters)
+	r.redactMap(e.Custom)
+	r.redactMap(e.Metadata)
+	if e.Actor != nil {
+		r.redactActor(e.Actor)
+	}
+	for _, fn := range r.custom {
+		fn(e)
+	}
+}
+
+func (r *redactor) redactMap(m map[string]interface{}) {
+	for k, v := range m {
+		if r.keyMatches(k) {
+			m[k] = redactedToken(k)
+			continue
+		}
+		m[k] = r.redactValue(v)
+	}
+}
+
+// redactValue applies redactString to a string value, or recurses into
+// nested maps and slices so a secret or PII value buried a few levels
+// deep in a structured parameter (e.g. Custom["user"]["email"]) is
+// redacted the same as a top-level one.
+func (r *redactor) redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case string:
+		return r.redactString(val)
+	case map[string]interface{}:
+		r.redactMap(val)
+		return val
+	case []interface{}:
+		for i, elem := range val {
+			val[i] = r.redactValue(elem)
+		}
+		return val
+	default:
+		return val
+	}
+}
+
+func (r *redactor) redactActor(a *ActorContext) {
+	if a.Email != "" {
+		if r.keyMatches("email") {
+			a.Email = redactedToken("email")
+		} else {
+			a.Email = r.redactString(a.Email)
+		}
+	}
+	if a.IPAddress != "" {
+		if r.keyMatches("ip_address") {
+			a.IPAddress = redactedToken("ip_address")
+		} else {
+			a.IPAddress = r.redactString(a.IPAddress)
+		}
+	}
+}
+
+func (r *redactor) keyMatches(key string) bool {
+	for _, glob := range r.keyGlobs {
+		if ok, _ := filepath.Match(strings.ToLower(glob), strings.ToLower(key)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *redactor) redactString(s string) string {
+	for _, rule := range r.rules {
+		s = rule.re.ReplaceAllString(s, rule.replacement)
+	}
+	return s
+}
+
+func redactedToken(key string) string {
+	return fmt.Sprintf("[REDACTED:%s]", key)
+}
@@ -0,0 +1,104 @@
+package audit
+
+import "testing"
+
+func TestRedactor_KeyMatch(t *testing.T) {
+	r := &redactor{}
+	WithRedactKeys("password", "*token*")(&Client{redact: r})
+
+	e := &AuditEvent{
+		Action: ActionContext{
+			Parameters: map[string]interface{}{
+				"password":     "hunter2",
+				"access_token": "abc123",
+				"username":     "alice",
+			},
+		},
+	}
+	r.redact(e)
+
+	if e.Action.Parameters["password"] != "[REDACTED:password]" {
+		t.Errorf("expected password to be redacted, got %v", e.Action.Parameters["password"])
+	}
+	if e.Action.Parameters["access_token"] != "[REDACTED:access_token]" {
+		t.Errorf("expected access_token to be redacted, got %v", e.Action.Parameters["access_token"])
+	}
+	if e.Action.Parameters["username"] != "alice" {
+		t.Errorf("expected username to be left alone, got %v", e.Action.Parameters["username"])
+	}
+}
+
+func TestRedactor_PatternMatch(t *testing.T) {
+	r := &redactor{}
+	c := &Client{redact: r}
+	RedactPII(false)(c)
+
+	e := &AuditEvent{
+		Actor: &ActorContext{Email: "alice@example.com"},
+		Action: ActionContext{
+			Parameters: map[string]interface{}{
+				"note": "contact alice@example.com for access",
+			},
+		},
+	}
+	r.redact(e)
+
+	if e.Actor.Email != "[REDACTED:email]" {
+		t.Errorf("expected actor email to be redacted by key name, got %v", e.Actor.Email)
+	}
+	if e.Action.Parameters["note"] != "contact [REDACTED:email] for access" {
+		t.Errorf("expected embedded email to be redacted by pattern, got %v", e.Action.Parameters["note"])
+	}
+}
+
+func TestRedactor_NestedStructures(t *testing.T) {
+	r := &redactor{}
+	c := &Client{redact: r}
+	WithRedactKeys("password")(c)
+	RedactPII(false)(c)
+
+	e := &AuditEvent{
+		Custom: map[string]interface{}{
+			"user": map[string]interface{}{
+				"email":    "alice@example.com",
+				"password": "hunter2",
+			},
+			"contacts": []interface{}{
+				map[string]interface{}{"email": "bob@example.com"},
+				"call carol@example.com",
+			},
+		},
+	}
+	r.redact(e)
+
+	user := e.Custom["user"].(map[string]interface{})
+	if user["email"] != "[REDACTED:email]" {
+		t.Errorf("expected nested map email to be redacted by key, got %v", user["email"])
+	}
+	if user["password"] != "[REDACTED:password]" {
+		t.Errorf("expected nested map password to be redacted by key, got %v", user["password"])
+	}
+
+	contacts := e.Custom["contacts"].([]interface{})
+	first := contacts[0].(map[string]interface{})
+	if first["email"] != "[REDACTED:email]" {
+		t.Errorf("expected map nested in a slice to be redacted, got %v", first["email"])
+	}
+	if contacts[1] != "call [REDACTED:email]" {
+		t.Errorf("expected string nested in a slice to be redacted, got %v", contacts[1])
+	}
+}
+
+func TestRedactor_NoRulesIsNoOp(t *testing.T) {
+	r := &redactor{}
+	e := &AuditEvent{
+		Action: ActionContext{
+			Parameters: map[string]interface{}{"password": "hunter2"},
+		},
+	}
+	r.redact(e)
+
+	if e.Action.Parameters["password"] != "hunter2" {
+		t.Errorf("expected no-op redactor to leave values untouched, got %v", e.Action.Parameters["password"])
+	}
+}
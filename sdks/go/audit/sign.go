@@ -0,0 +1,194 @@
+package audit
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// Signer produces a signature over canonicalBytes, the canonical JSON
+// encoding of an event produced by canonicalizeEvent. alg identifies the
+// signing scheme to verifiers (e.g. "HMAC-SHA256", "Ed25519").
+type Signer interface {
+	Sign(canonicalBytes []byte) (alg string, sig []byte)
+}
+
+// KeyIdentifier is implemented by Signers that tag their signatures with
+// a key ID, so a verifier can pick the right key out of a rotation.
+// Client.Log surfaces it as EventSignature.KeyID when present.
+type KeyIdentifier interface {
+	KeyID() string
+}
+
+// Verifier checks a signature produced by a Signer against the same
+// canonical bytes that were signed.
+type Verifier interface {
+	Verify(canonicalBytes, sig []byte) bool
+}
+
+// WithSigner enables tamper-evident signing: every event Logged (or
+// LogAsync'd) through this Client is signed with signer before it is
+// sent, and its EventSignature.PrevHash chains to the previous signed
+// event so a collector (or VerifyEvent) can detect a dropped or
+// reordered event.
+func WithSigner(signer Signer) ClientOption {
+	return func(c *Client) { c.signer = signer }
+}
+
+type hmacSigner struct {
+	key   []byte
+	keyID string
+}
+
+// HMACSigner signs events with HMAC-SHA256 using key. keyID is attached
+// to each signature so a verifier can pick the right key out of a
+// rotation; pass "" if there is only one key.
+func HMACSigner(key []byte, keyID string) Signer {
+	return &hmacSigner{key: key, keyID: keyID}
+}
+
+// HMACVerifier builds a Verifier for signatures produced by an
+// HMACSigner with the same key. HMAC is symmetric, so signing and
+// verifying share key material.
+func HMACVerifier(key []byte) Verifier {
+	return &hmacSigner{key: key}
+}
+
+func (s *hmacSigner) Sign(canonicalBytes []byte) (string, []byte) {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(canonicalBytes)
+	return "HMAC-SHA256", mac.Sum(nil)
+}
+
+func (s *hmacSigner) Verify(canonicalBytes, sig []byte) bool {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(canonicalBytes)
+	return hmac.Equal(mac.Sum(nil), sig)
+}
+
+func (s *hmacSigner) KeyID() string { return s.keyID }
+
+type ed25519Signer struct {
+	priv  ed25519.PrivateKey
+	keyID string
+}
+
+// Ed25519Signer signs events with Ed25519 using priv. keyID is attached
+// to each signature so a verifier can pick the right public key out of a
+// rotation; pass "" if there is only one key.
+func Ed25519Signer(priv ed25519.PrivateKey, keyID string) Signer {
+	return &ed25519Signer{priv: priv, keyID: keyID}
+}
+
+func (s *ed25519Signer) Sign(canonicalBytes []byte) (string, []byte) {
+	return "Ed25519", ed25519.Sign(s.priv, canonicalBytes)
+}
+
+func (s *ed25519Signer) KeyID() string { return s.keyID }
+
+type ed25519Verifier struct {
+	pub ed25519.PublicKey
+}
+
+// Ed25519Verifier builds a Verifier for signatures produced by
+// Ed25519Signer using the matching public key.
+func Ed25519Verifier(pub ed25519.PublicKey) Verifier {
+	return &ed25519Verifier{pub: pub}
+}
+
+func (v *ed25519Verifier) Verify(canonicalBytes, sig []byte) bool {
+	return ed25519.Verify(v.pub, canonicalBytes, sig)
+}
+
+// sign attaches event.Signature, chaining PrevHash to the signature of
+// the last event this Client signed. It is a no-op without WithSigner.
+func (c *Client) sign(event *AuditEvent) error {
+	if c.signer == nil {
+		return nil
+	}
+
+	canon, err := canonicalizeEvent(event)
+	if err != nil {
+		return fmt.Errorf("canonicalize event: %w", err)
+	}
+
+	c.signMu.Lock()
+	prevHash := c.prevSigHash
+	alg, sig := c.signer.Sign(canon)
+	hash := sha256.Sum256(sig)
+	c.prevSigHash = hash[:]
+	c.signMu.Unlock()
+
+	sigInfo := &EventSignature{
+		Algorithm: alg,
+		Signature: base64.StdEncoding.EncodeToString(sig),
+	}
+	if prevHash != nil {
+		sigInfo.PrevHash = base64.StdEncoding.EncodeToString(prevHash)
+	}
+	if ki, ok := c.signer.(KeyIdentifier); ok {
+		sigInfo.KeyID = ki.KeyID()
+	}
+	event.Signature = sigInfo
+	return nil
+}
+
+// VerifyEvent re-canonicalizes e (with Signature stripped, exactly as
+// Client.Log did before signing) and checks the result against
+// e.Signature using verifier. It returns a descriptive error rather than
+// a bare boolean so callers can log why a chain broke.
+func VerifyEvent(e *AuditEvent, verifier Verifier) error {
+	if e.Signature == nil {
+		return fmt.Errorf("event %s has no signature", e.EventID)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(e.Signature.Signature)
+	if err != nil {
+		return fmt.Errorf("decode signature: %w", err)
+	}
+
+	canon, err := canonicalizeEvent(e)
+	if err != nil {
+		return fmt.Errorf("canonicalize event: %w", err)
+	}
+
+	if !verifier.Verify(canon, sig) {
+		return fmt.Errorf("signature does not match event %s", e.EventID)
+	}
+	return nil
+}
+
+// canonicalizeEvent encodes e (with its Signature field stripped) as
+// canonical JSON, approximating RFC 8785 (JCS): object keys sorted
+// lexicographically at every nesting level, UTF-8, and no insignificant
+// whitespace. It relies on encoding/json's behavior of sorting
+// map[string]interface{} keys on Marshal: the event is first marshaled
+// normally, then round-tripped through a generic interface{} so every
+// nested struct becomes a map the second Marshal can sort. Go's number
+// formatting (shortest round-trip decimal) matches JCS for the float64
+// range; values outside that range are not expected in audit events.
+// Cross-language verifiers (e.g. the Python SDK) must reproduce this
+// exact encoding to validate signatures produced here.
+func canonicalizeEvent(e *AuditEvent) ([]byte, error) {
+	stripped := *e
+	stripped.Signature = nil
+
+	raw, err := json.Marshal(&stripped)
+	if err != nil {
+		return nil, fmt.Errorf("marshal event: %w", err)
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil, fmt.Errorf("decode event: %w", err)
+	}
+
+	canon, err := json.Marshal(generic)
+	if err != nil {
+		return nil, fmt.Errorf("marshal canonical event: %w", err)
+	}
+	return canon, nil
+}
@@ -0,0 +1,79 @@
+package audit
+
+import "testing"
+
+func TestHMACSigner_SignAndVerify(t *testing.T) {
+	key := []byte("test-key")
+	signer := HMACSigner(key, "key-1")
+
+	e := &AuditEvent{
+		EventID: "evt-1",
+		Action: ActionContext{
+			Type:      "READ",
+			Category:  "DATABASE",
+			Operation: "query_users",
+		},
+	}
+
+	canon, err := canonicalizeEvent(e)
+	if err != nil {
+		t.Fatalf("canonicalizeEvent: %v", err)
+	}
+	alg, sig := signer.Sign(canon)
+	if alg != "HMAC-SHA256" {
+		t.Fatalf("expected alg HMAC-SHA256, got %q", alg)
+	}
+
+	verifier := HMACVerifier(key)
+	if !verifier.Verify(canon, sig) {
+		t.Fatal("expected signature to verify with the same key")
+	}
+
+	wrongVerifier := HMACVerifier([]byte("wrong-key"))
+	if wrongVerifier.Verify(canon, sig) {
+		t.Fatal("expected signature to fail verification with the wrong key")
+	}
+}
+
+func TestClient_SignChainsPrevHash(t *testing.T) {
+	c := &Client{signer: HMACSigner([]byte("k"), "")}
+
+	e1 := &AuditEvent{EventID: "evt-1", Action: ActionContext{Type: "READ", Category: "DATABASE", Operation: "op1"}}
+	e2 := &AuditEvent{EventID: "evt-2", Action: ActionContext{Type: "READ", Category: "DATABASE", Operation: "op2"}}
+
+	if err := c.sign(e1); err != nil {
+		t.Fatalf("sign e1: %v", err)
+	}
+	if e1.Signature.PrevHash != "" {
+		t.Fatalf("expected first event to have no prev_hash, got %q", e1.Signature.PrevHash)
+	}
+
+	if err := c.sign(e2); err != nil {
+		t.Fatalf("sign e2: %v", err)
+	}
+	if e2.Signature.PrevHash == "" {
+		t.Fatal("expected second event to chain to the first via prev_hash")
+	}
+}
+
+func TestVerifyEvent(t *testing.T) {
+	key := []byte("test-key")
+	c := &Client{signer: HMACSigner(key, "key-1")}
+
+	e := &AuditEvent{
+		EventID: "evt-1",
+		Action:  ActionContext{Type: "READ", Category: "DATABASE", Operation: "query_users"},
+	}
+	if err := c.sign(e); err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+
+	if err := VerifyEvent(e, HMACVerifier(key)); err != nil {
+		t.Fatalf("expected signature to verify, got: %v", err)
+	}
+
+	e.Action.Operation = "tampered"
+	if err := VerifyEvent(e, HMACVerifier(key)); err == nil {
+		t.Fatal("expected verification to fail after the event was tampered with")
+	}
+}
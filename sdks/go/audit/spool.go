@@ -0,0 +1,300 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// spoolWAL is an append-only, file-backed queue of events that have not
+// yet been confirmed delivered to the collector. Events are stored as
+// newline-delimited JSON across rotating segment files so a crash between
+// enqueue and delivery does not lose data.
+type spoolWAL struct {
+	dir      string
+	maxBytes int64
+
+	mu        sync.Mutex
+	segments  []string       // ordered, oldest first
+	segCounts map[string]int // pending (un-acked) event count per segment, keyed by path
+	cur       *os.File
+	curSize   int64
+	curSeq    int
+}
+
+func openSpoolWAL(dir string, maxBytes int64) (*spoolWAL, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	w := &spoolWAL{dir: dir, maxBytes: maxBytes, segCounts: make(map[string]int)}
+	if err := w.loadSegments(); err != nil {
+		return nil, err
+	}
+	if err := w.openNewSegment(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *spoolWAL) loadSegments() error {
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), "events-") || !strings.HasSuffix(e.Name(), ".jsonl") {
+			continue
+		}
+		w.segments = append(w.segments, filepath.Join(w.dir, e.Name()))
+	}
+	sort.Strings(w.segments)
+	for _, s := range w.segments {
+		if seq := segmentSeq(s); seq > w.curSeq {
+			w.curSeq = seq
+		}
+	}
+	return nil
+}
+
+func segmentSeq(path string) int {
+	base := strings.TrimSuffix(filepath.Base(path), ".jsonl")
+	base = strings.TrimPrefix(base, "events-")
+	n, _ := strconv.Atoi(base)
+	return n
+}
+
+func (w *spoolWAL) openNewSegment() error {
+	w.curSeq++
+	path := filepath.Join(w.dir, fmt.Sprintf("events-%08d.jsonl", w.curSeq))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	w.cur = f
+	w.curSize = 0
+	w.segments = append(w.segments, path)
+	return nil
+}
+
+// Append writes event to the current segment, fsyncing before returning so
+// the event is durable before the caller's in-memory queue slot is
+// considered safe.
+func (w *spoolWAL) Append(event AuditEvent) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.appendLocked(event)
+}
+
+func (w *spoolWAL) appendLocked(event AuditEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal event for spool: %w", err)
+	}
+	line = append(line, '\n')
+
+	if w.maxBytes > 0 && w.curSize+int64(len(line)) > w.maxBytes {
+		oldPath := w.activeSegment()
+		w.cur.Close()
+		if count, everAppended := w.segCounts[oldPath]; everAppended && count == 0 {
+			// Nothing left pending in the segment we're rotating away
+			// from (everything in it was already Acked); drop it now
+			// instead of leaving a fully-delivered file on disk until
+			// some later Ack happens to notice it.
+			w.removeSegment(oldPath)
+		}
+		if err := w.openNewSegment(); err != nil {
+			return fmt.Errorf("rotate spool segment: %w", err)
+		}
+	}
+
+	if _, err := w.cur.Write(line); err != nil {
+		return fmt.Errorf("write spool segment: %w", err)
+	}
+	if err := w.cur.Sync(); err != nil {
+		return fmt.Errorf("fsync spool segment: %w", err)
+	}
+	w.curSize += int64(len(line))
+	w.segCounts[w.activeSegment()]++
+	return nil
+}
+
+// activeSegment returns the path currently being written to, or "" if no
+// segment is open yet.
+func (w *spoolWAL) activeSegment() string {
+	if len(w.segments) == 0 {
+		return ""
+	}
+	return w.segments[len(w.segments)-1]
+}
+
+// removeSegment deletes path from disk and drops its bookkeeping. Callers
+// must hold w.mu and must not call this on the active segment while it is
+// still open for writing.
+func (w *spoolWAL) removeSegment(path string) {
+	for i, s := range w.segments {
+		if s == path {
+			w.segments = append(w.segments[:i], w.segments[i+1:]...)
+			break
+		}
+	}
+	delete(w.segCounts, path)
+	os.Remove(path)
+}
+
+// Pending replays every event currently on disk, oldest first. It is
+// called once at startup to re-ingest events that survived a crash.
+func (w *spoolWAL) Pending() ([]AuditEvent, error) {
+	w.mu.Lock()
+	segments := append([]string(nil), w.segments...)
+	w.mu.Unlock()
+
+	var events []AuditEvent
+	for _, path := range segments {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("open spool segment %s: %w", path, err)
+		}
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			var e AuditEvent
+			if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+				continue // skip a truncated/corrupt line rather than fail startup
+			}
+			events = append(events, e)
+		}
+		f.Close()
+	}
+	return events, nil
+}
+
+// Recover replays every event currently on disk (oldest first) and hands
+// the batcher a spool it can keep appending to. Unlike a plain
+// Pending+discard, it re-appends the recovered events to a fresh segment
+// and fsyncs them there *before* removing the old segments, so a second
+// crash during recovery still leaves every event recoverable from either
+// the old or the new segment — there is no window where they exist only
+// in memory. It is called once at startup.
+func (w *spoolWAL) Recover() ([]AuditEvent, error) {
+	events, err := w.Pending()
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	oldSegments := append([]string(nil), w.segments...)
+	if w.cur != nil {
+		w.cur.Close()
+	}
+	w.segments = nil
+	w.segCounts = make(map[string]int)
+	// curSeq is left as-is (already the highest sequence number seen by
+	// loadSegments) so the fresh segment gets a name past every old one;
+	// resetting it to 0 would make openNewSegment reuse an old segment's
+	// filename, appending into the very file Recover is about to remove.
+	if err := w.openNewSegment(); err != nil {
+		return nil, fmt.Errorf("open fresh segment for recovery: %w", err)
+	}
+	for _, e := range events {
+		if err := w.appendLocked(e); err != nil {
+			return nil, fmt.Errorf("re-append recovered event to fresh segment: %w", err)
+		}
+	}
+
+	for _, s := range oldSegments {
+		os.Remove(s)
+	}
+	return events, nil
+}
+
+// Ack marks the oldest n events as done — either delivered successfully or
+// handed to OnError after the retry policy gave up on them — and compacts
+// any segment that becomes fully acked as a result. Callers must always ack
+// in the same oldest-first order events were returned by Pending/Recover
+// and enqueued to the batcher, since Ack has no way to identify individual
+// events once they're on disk; asyncBatcher.send relies on events reaching
+// the WAL in the same order they reach queueCh to keep this invariant.
+//
+// A segment with events still pending after this call is left on disk
+// untouched. A segment that's now fully acked is removed entirely, unless
+// it's the segment currently open for writes — which is truncated in place
+// instead, so the WAL keeps shrinking during normal operation even when
+// nothing ever rotates it to a new file (e.g. maxBytes is 0).
+//
+// Acks that land in the middle of a segment (some of its events acked,
+// some not) reduce that segment's pending count but don't rewrite the file,
+// so its already-acked bytes stay on disk until the rest of the segment
+// drains too. That's a bounded amount of slack, not an unbounded one: each
+// segment is capped by maxBytes (or fully drains on its own), so the WAL
+// still stops growing without needing crash-safe sub-segment bookkeeping.
+func (w *spoolWAL) Ack(n int) error {
+	if n <= 0 {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	active := w.activeSegment()
+	remaining := n
+	var kept []string
+	for _, path := range w.segments {
+		if remaining <= 0 {
+			kept = append(kept, path)
+			continue
+		}
+
+		count := w.segCounts[path]
+		if remaining < count {
+			w.segCounts[path] = count - remaining
+			remaining = 0
+			kept = append(kept, path)
+			continue
+		}
+
+		remaining -= count
+		if path != active {
+			delete(w.segCounts, path)
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("compact acked spool segment %s: %w", path, err)
+			}
+			continue
+		}
+
+		// The active segment is fully acked but still open for writes,
+		// so it can't be removed outright; truncate it back to empty
+		// instead, which reclaims the disk space without disturbing the
+		// open file handle or the segment's name/sequence number.
+		w.segCounts[path] = 0
+		if err := w.cur.Truncate(0); err != nil {
+			return fmt.Errorf("truncate acked spool segment %s: %w", path, err)
+		}
+		if _, err := w.cur.Seek(0, 0); err != nil {
+			return fmt.Errorf("seek acked spool segment %s: %w", path, err)
+		}
+		w.curSize = 0
+		kept = append(kept, path)
+	}
+	w.segments = kept
+	return nil
+}
+
+func (w *spoolWAL) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.cur != nil {
+		return w.cur.Close()
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+package audit
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSpoolWAL_AppendAndPending(t *testing.T) {
+	dir := t.TempDir()
+	wal, err := openSpoolWAL(dir, 0)
+	if err != nil {
+		t.Fatalf("openSpoolWAL: %v", err)
+	}
+	defer wal.Close()
+
+	for _, id := range []string{"evt-1", "evt-2", "evt-3"} {
+		if err := wal.Append(AuditEvent{EventID: id}); err != nil {
+			t.Fatalf("Append(%s): %v", id, err)
+		}
+	}
+
+	pending, err := wal.Pending()
+	if err != nil {
+		t.Fatalf("Pending: %v", err)
+	}
+	if len(pending) != 3 {
+		t.Fatalf("expected 3 pending events, got %d", len(pending))
+	}
+	for i, id := range []string{"evt-1", "evt-2", "evt-3"} {
+		if pending[i].EventID != id {
+			t.Errorf("expected pending[%d].EventID = %q, got %q", i, id, pending[i].EventID)
+		}
+	}
+}
+
+func TestSpoolWAL_PendingSurvivesReopen(t *testing.T) {
+	dir := t.TempDir()
+	wal, err := openSpoolWAL(dir, 0)
+	if err != nil {
+		t.Fatalf("openSpoolWAL: %v", err)
+	}
+	if err := wal.Append(AuditEvent{EventID: "evt-1"}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if err := wal.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	reopened, err := openSpoolWAL(dir, 0)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer reopened.Close()
+
+	pending, err := reopened.Pending()
+	if err != nil {
+		t.Fatalf("Pending: %v", err)
+	}
+	if len(pending) != 1 || pending[0].EventID != "evt-1" {
+		t.Fatalf("expected the appended event to survive a reopen, got %+v", pending)
+	}
+}
+
+// TestSpoolWAL_RecoverKeepsEventsRecoverableUntilReAppended guards against
+// recovery deleting the only durable copy of an event before a fresh one
+// exists: every recovered event must be readable back from disk via a
+// second WAL handle even if nothing ever drains the first.
+func TestSpoolWAL_RecoverKeepsEventsRecoverableUntilReAppended(t *testing.T) {
+	dir := t.TempDir()
+	wal, err := openSpoolWAL(dir, 0)
+	if err != nil {
+		t.Fatalf("openSpoolWAL: %v", err)
+	}
+	if err := wal.Append(AuditEvent{EventID: "evt-1"}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if err := wal.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	reopened, err := openSpoolWAL(dir, 0)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer reopened.Close()
+
+	recovered, err := reopened.Recover()
+	if err != nil {
+		t.Fatalf("Recover: %v", err)
+	}
+	if len(recovered) != 1 || recovered[0].EventID != "evt-1" {
+		t.Fatalf("expected Recover to return the spooled event, got %+v", recovered)
+	}
+
+	// A third handle, opened without ever calling Recover, must still find
+	// the event durably on disk: Recover's re-append happened before it
+	// deleted the original segment.
+	third, err := openSpoolWAL(dir, 0)
+	if err != nil {
+		t.Fatalf("third open: %v", err)
+	}
+	defer third.Close()
+
+	pending, err := third.Pending()
+	if err != nil {
+		t.Fatalf("Pending: %v", err)
+	}
+	if len(pending) != 1 || pending[0].EventID != "evt-1" {
+		t.Fatalf("expected the recovered event to still be on disk, got %+v", pending)
+	}
+}
+
+func TestSpoolWAL_RecoverIsNoOpWhenEmpty(t *testing.T) {
+	dir := t.TempDir()
+	wal, err := openSpoolWAL(dir, 0)
+	if err != nil {
+		t.Fatalf("openSpoolWAL: %v", err)
+	}
+	defer wal.Close()
+
+	recovered, err := wal.Recover()
+	if err != nil {
+		t.Fatalf("Recover: %v", err)
+	}
+	if len(recovered) != 0 {
+		t.Fatalf("expected no recovered events from an empty spool, got %d", len(recovered))
+	}
+}
+
+func TestSpoolWAL_AckOfWholeSegmentRemovesItAfterReopen(t *testing.T) {
+	dir := t.TempDir()
+	wal, err := openSpoolWAL(dir, 0)
+	if err != nil {
+		t.Fatalf("openSpoolWAL: %v", err)
+	}
+	defer wal.Close()
+
+	for _, id := range []string{"evt-1", "evt-2", "evt-3"} {
+		if err := wal.Append(AuditEvent{EventID: id}); err != nil {
+			t.Fatalf("Append(%s): %v", id, err)
+		}
+	}
+
+	// Ack all three, as if the whole batch had just been delivered.
+	if err := wal.Ack(3); err != nil {
+		t.Fatalf("Ack: %v", err)
+	}
+
+	pending, err := wal.Pending()
+	if err != nil {
+		t.Fatalf("Pending: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Fatalf("expected no events pending right after a full Ack, got %+v", pending)
+	}
+
+	// Reopening against the same dir must not resurrect the acked events.
+	reopened, err := openSpoolWAL(dir, 0)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer reopened.Close()
+
+	recovered, err := reopened.Recover()
+	if err != nil {
+		t.Fatalf("Recover: %v", err)
+	}
+	if len(recovered) != 0 {
+		t.Fatalf("expected nothing to survive a reopen after a full Ack, got %+v", recovered)
+	}
+}
+
+// TestSpoolWAL_PartialAckDoesNotDropStillPendingEvents guards against
+// over-eager compaction: acking fewer events than are in the active
+// segment must not truncate or delete it out from under the events still
+// waiting on an Ack, since a crash right after would lose them.
+func TestSpoolWAL_PartialAckDoesNotDropStillPendingEvents(t *testing.T) {
+	dir := t.TempDir()
+	wal, err := openSpoolWAL(dir, 0)
+	if err != nil {
+		t.Fatalf("openSpoolWAL: %v", err)
+	}
+	defer wal.Close()
+
+	for _, id := range []string{"evt-1", "evt-2", "evt-3"} {
+		if err := wal.Append(AuditEvent{EventID: id}); err != nil {
+			t.Fatalf("Append(%s): %v", id, err)
+		}
+	}
+
+	// Ack the first two, as if a batch of them had just been delivered;
+	// the third was never sent (e.g. still queued behind them).
+	if err := wal.Ack(2); err != nil {
+		t.Fatalf("Ack: %v", err)
+	}
+
+	// The segment can't be compacted yet (evt-3 is still pending), so a
+	// crash-and-reopen right now must still find it.
+	reopened, err := openSpoolWAL(dir, 0)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer reopened.Close()
+
+	recovered, err := reopened.Recover()
+	if err != nil {
+		t.Fatalf("Recover: %v", err)
+	}
+	var ids []string
+	for _, e := range recovered {
+		ids = append(ids, e.EventID)
+	}
+	found := false
+	for _, id := range ids {
+		if id == "evt-3" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected the still-pending evt-3 to survive a crash mid-Ack, got %+v", ids)
+	}
+}
+
+func TestSpoolWAL_AckOfEverythingShrinksDiskUsage(t *testing.T) {
+	dir := t.TempDir()
+	wal, err := openSpoolWAL(dir, 0)
+	if err != nil {
+		t.Fatalf("openSpoolWAL: %v", err)
+	}
+	defer wal.Close()
+
+	for i := 0; i < 5; i++ {
+		if err := wal.Append(AuditEvent{EventID: "evt"}); err != nil {
+			t.Fatalf("Append %d: %v", i, err)
+		}
+	}
+	before, err := diskUsage(dir)
+	if err != nil {
+		t.Fatalf("diskUsage: %v", err)
+	}
+
+	if err := wal.Ack(5); err != nil {
+		t.Fatalf("Ack: %v", err)
+	}
+	after, err := diskUsage(dir)
+	if err != nil {
+		t.Fatalf("diskUsage: %v", err)
+	}
+	if after >= before {
+		t.Errorf("expected fully-acked WAL to shrink on disk, got %d bytes before and %d after", before, after)
+	}
+
+	pending, err := wal.Pending()
+	if err != nil {
+		t.Fatalf("Pending: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Fatalf("expected no events pending after fully acking, got %+v", pending)
+	}
+}
+
+func diskUsage(dir string) (int64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil {
+			return 0, err
+		}
+		total += info.Size()
+	}
+	return total, nil
+}
+
+func TestSpoolWAL_AppendRotatesOnMaxBytes(t *testing.T) {
+	dir := t.TempDir()
+	wal, err := openSpoolWAL(dir, 1) // rotate after every write
+	if err != nil {
+		t.Fatalf("openSpoolWAL: %v", err)
+	}
+	defer wal.Close()
+
+	for i := 0; i < 3; i++ {
+		if err := wal.Append(AuditEvent{EventID: "evt"}); err != nil {
+			t.Fatalf("Append %d: %v", i, err)
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+	if len(entries) != 4 {
+		t.Errorf("expected rotation to produce 4 segment files, got %d", len(entries))
+	}
+}
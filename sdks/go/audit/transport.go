@@ -0,0 +1,115 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Transport abstracts the wire protocol used to deliver events to a
+// collector. It is the seam that used to be Client.doPost, pulled out so
+// alternate protocols (gRPC, local file shipping, ...) can be swapped in
+// with WithTransport without touching the rest of the SDK.
+type Transport interface {
+	// Send delivers events and returns the collector's response. A single
+	// event is sent as a one-element slice.
+	Send(ctx context.Context, events []AuditEvent) (*BatchResponse, error)
+}
+
+// WithTransport overrides the Transport used to deliver events. The
+// URL-based NewClient constructor is sugar for
+// WithTransport(NewHTTPTransport(url, apiKey, httpClient)).
+func WithTransport(t Transport) ClientOption {
+	return func(c *Client) { c.transport = t }
+}
+
+// HTTPTransport is the default Transport: it POSTs events as JSON to the
+// collector's REST API, using /api/v1/events for a single event and
+// /api/v1/events/batch otherwise.
+type HTTPTransport struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewHTTPTransport builds the default REST transport against collectorURL.
+func NewHTTPTransport(collectorURL, apiKey string, httpClient *http.Client) *HTTPTransport {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &HTTPTransport{baseURL: collectorURL, apiKey: apiKey, httpClient: httpClient}
+}
+
+func (t *HTTPTransport) Send(ctx context.Context, events []AuditEvent) (*BatchResponse, error) {
+	if len(events) == 1 {
+		return t.sendSingle(ctx, events[0])
+	}
+	return t.sendBatch(ctx, events)
+}
+
+func (t *HTTPTransport) sendSingle(ctx context.Context, event AuditEvent) (*BatchResponse, error) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("marshal event: %w", err)
+	}
+
+	resp, err := t.doPost(ctx, "/api/v1/events", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result IngestResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return &BatchResponse{Status: result.Status, Accepted: 1, EventIDs: []string{result.EventID}}, nil
+}
+
+func (t *HTTPTransport) sendBatch(ctx context.Context, events []AuditEvent) (*BatchResponse, error) {
+	body, err := json.Marshal(BatchRequest{Events: events})
+	if err != nil {
+		return nil, fmt.Errorf("marshal batch: %w", err)
+	}
+
+	resp, err := t.doPost(ctx, "/api/v1/events/batch", body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result BatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return &result, nil
+}
+
+func (t *HTTPTransport) doPost(ctx context.Context, path string, body []byte) (*http.Response, error) {
+	url := t.baseURL + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if t.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+t.apiKey)
+	}
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send request to %s: %w", url, err)
+	}
+
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, &CollectorError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,91 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileTransport writes events as newline-delimited JSON to a rotating
+// file instead of calling a collector directly. It suits air-gapped or
+// sidecar-shipping deployments, where a local agent (filebeat, vector,
+// ...) tails the file and forwards it upstream.
+type FileTransport struct {
+	dir      string
+	maxBytes int64
+
+	mu      sync.Mutex
+	cur     *os.File
+	curSize int64
+	seq     int
+}
+
+// NewFileTransport opens (creating if necessary) a rotating NDJSON
+// segment under dir. maxBytes rotates the active segment once exceeded;
+// 0 disables rotation.
+func NewFileTransport(dir string, maxBytes int64) (*FileTransport, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create file transport dir %s: %w", dir, err)
+	}
+	t := &FileTransport{dir: dir, maxBytes: maxBytes}
+	if err := t.openSegment(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *FileTransport) openSegment() error {
+	t.seq++
+	path := filepath.Join(t.dir, fmt.Sprintf("audit-%08d.jsonl", t.seq))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open file transport segment %s: %w", path, err)
+	}
+	t.cur = f
+	t.curSize = 0
+	return nil
+}
+
+// Send appends events to the current segment, rotating if needed. It
+// never contacts a network collector, so transient network failures
+// cannot occur; the returned BatchResponse always reports every event as
+// accepted.
+func (t *FileTransport) Send(ctx context.Context, events []AuditEvent) (*BatchResponse, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	result := &BatchResponse{Status: "ok"}
+	for _, e := range events {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return nil, fmt.Errorf("marshal event: %w", err)
+		}
+		line = append(line, '\n')
+
+		if t.maxBytes > 0 && t.curSize+int64(len(line)) > t.maxBytes {
+			t.cur.Close()
+			if err := t.openSegment(); err != nil {
+				return nil, err
+			}
+		}
+
+		if _, err := t.cur.Write(line); err != nil {
+			return nil, fmt.Errorf("write event: %w", err)
+		}
+		t.curSize += int64(len(line))
+
+		result.Accepted++
+		result.EventIDs = append(result.EventIDs, e.EventID)
+	}
+	return result, nil
+}
+
+// Close closes the active segment file.
+func (t *FileTransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.cur.Close()
+}
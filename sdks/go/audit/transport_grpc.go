@@ -0,0 +1,196 @@
+//go:build vigil_grpc
+
+// Package audit's gRPC transport depends on auditpb, the code generated
+// from proto/audit.proto by `protoc --go_out=... --go-grpc_out=...`.
+// That generated
+// package isn't checked in, so this file is gated behind the vigil_grpc
+// build tag: the core SDK (HTTPTransport, FileTransport, and everything
+// else) builds and tests without it, and only `go build -tags vigil_grpc`
+// needs the generated stubs and google.golang.org/grpc on GOPATH.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc"
+
+	"github.com/PolycarpusTack/vigil/sdks/go/audit/proto/auditpb"
+)
+
+// GRPCTransport delivers events over the bidirectional stream defined by
+// proto/audit.proto (see auditpb, generated via the vigil_grpc build
+// tag's prerequisites), instead of
+// the default HTTP+JSON transport.
+type GRPCTransport struct {
+	client auditpb.AuditBatchServiceClient
+}
+
+// NewGRPCTransport wraps an already-dialed gRPC connection. The caller
+// owns the connection's lifecycle (dialing and closing it).
+func NewGRPCTransport(conn *grpc.ClientConn) *GRPCTransport {
+	return &GRPCTransport{client: auditpb.NewAuditBatchServiceClient(conn)}
+}
+
+func (t *GRPCTransport) Send(ctx context.Context, events []AuditEvent) (*BatchResponse, error) {
+	stream, err := t.client.StreamEvents(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("open audit event stream: %w", err)
+	}
+
+	for i := range events {
+		pbEvent, err := toProtoEvent(&events[i])
+		if err != nil {
+			return nil, fmt.Errorf("encode event[%d]: %w", i, err)
+		}
+		if err := stream.Send(pbEvent); err != nil {
+			return nil, fmt.Errorf("send event[%d]: %w", i, err)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, fmt.Errorf("close audit event stream: %w", err)
+	}
+
+	result := &BatchResponse{Status: "ok"}
+	for {
+		ack, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("receive ack: %w", err)
+		}
+		if ack.Error != "" {
+			result.Errors = append(result.Errors, BatchError{Index: int(ack.Index), Error: ack.Error})
+			continue
+		}
+		result.Accepted++
+		result.EventIDs = append(result.EventIDs, ack.EventId)
+	}
+	return result, nil
+}
+
+// toProtoEvent converts an AuditEvent to its wire representation. The
+// free-form maps (System, Custom, Metadata) are carried as embedded JSON
+// rather than modeled field-by-field in the schema.
+func toProtoEvent(e *AuditEvent) (*auditpb.Event, error) {
+	systemJSON, err := marshalMapJSON(e.System)
+	if err != nil {
+		return nil, fmt.Errorf("encode system: %w", err)
+	}
+	customJSON, err := marshalMapJSON(e.Custom)
+	if err != nil {
+		return nil, fmt.Errorf("encode custom: %w", err)
+	}
+	metadataJSON, err := marshalMapJSON(e.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("encode metadata: %w", err)
+	}
+	parametersJSON, err := marshalMapJSON(e.Action.Parameters)
+	if err != nil {
+		return nil, fmt.Errorf("encode action parameters: %w", err)
+	}
+
+	pb := &auditpb.Event{
+		EventId:      e.EventID,
+		Timestamp:    e.Timestamp,
+		Version:      e.Version,
+		SystemJson:   systemJSON,
+		CustomJson:   customJSON,
+		MetadataJson: metadataJSON,
+		Action: &auditpb.ActionContext{
+			Type:           e.Action.Type,
+			Category:       e.Action.Category,
+			Operation:      e.Action.Operation,
+			Description:    e.Action.Description,
+			ParametersJson: parametersJSON,
+		},
+	}
+
+	if e.Session != nil {
+		pb.Session = &auditpb.SessionContext{
+			SessionId:     e.Session.SessionID,
+			RequestId:     e.Session.RequestID,
+			CorrelationId: e.Session.CorrelationID,
+		}
+	}
+	if e.Actor != nil {
+		pb.Actor = &auditpb.ActorContext{
+			Type:      e.Actor.Type,
+			Id:        e.Actor.ID,
+			Username:  e.Actor.Username,
+			Email:     e.Actor.Email,
+			Roles:     e.Actor.Roles,
+			IpAddress: e.Actor.IPAddress,
+			UserAgent: e.Actor.UserAgent,
+		}
+	}
+	if e.Action.Resource != nil {
+		pb.Action.Resource = &auditpb.ResourceInfo{
+			Type: e.Action.Resource.Type,
+			Id:   e.Action.Resource.ID,
+			Name: e.Action.Resource.Name,
+			Path: e.Action.Resource.Path,
+		}
+	}
+	if e.Action.Result != nil {
+		pb.Action.Result = &auditpb.ActionResult{
+			Status:        e.Action.Result.Status,
+			Code:          e.Action.Result.Code,
+			Message:       e.Action.Result.Message,
+			RowsAffected:  intPtrToInt32Ptr(e.Action.Result.RowsAffected),
+			DataSizeBytes: intPtrToInt32Ptr(e.Action.Result.DataSizeBytes),
+		}
+	}
+	if e.Performance != nil {
+		pb.Performance = &auditpb.PerformanceMetrics{
+			DurationMs:        e.Performance.DurationMS,
+			CpuTimeMs:         e.Performance.CPUTimeMS,
+			MemoryMb:          e.Performance.MemoryMB,
+			SlowQuery:         e.Performance.SlowQuery,
+			ThresholdExceeded: e.Performance.ThresholdExceeded,
+		}
+	}
+	if e.Error != nil {
+		pb.Error = &auditpb.ErrorInfo{
+			Occurred:   e.Error.Occurred,
+			Type:       e.Error.Type,
+			Message:    e.Error.Message,
+			StackTrace: e.Error.StackTrace,
+			Handled:    e.Error.Handled,
+		}
+	}
+	if e.Signature != nil {
+		pb.Signature = &auditpb.EventSignature{
+			Alg:      e.Signature.Algorithm,
+			Sig:      e.Signature.Signature,
+			KeyId:    e.Signature.KeyID,
+			PrevHash: e.Signature.PrevHash,
+		}
+	}
+
+	return pb, nil
+}
+
+// intPtrToInt32Ptr adapts the SDK's *int fields to the *int32 the
+// generated proto3 optional accessors expect.
+func intPtrToInt32Ptr(p *int) *int32 {
+	if p == nil {
+		return nil
+	}
+	v := int32(*p)
+	return &v
+}
+
+func marshalMapJSON(m map[string]interface{}) (string, error) {
+	if len(m) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPTransport_SendSingleUsesEventsEndpoint(t *testing.T) {
+	var gotPath string
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		json.NewEncoder(w).Encode(IngestResponse{Status: "ok", EventID: "evt-1"})
+	}))
+	defer srv.Close()
+
+	tr := NewHTTPTransport(srv.URL, "secret", nil)
+	resp, err := tr.Send(context.Background(), []AuditEvent{{EventID: "evt-1"}})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	if gotPath != "/api/v1/events" {
+		t.Errorf("expected single-event send to hit /api/v1/events, got %q", gotPath)
+	}
+	if gotAuth != "Bearer secret" {
+		t.Errorf("expected Authorization header with api key, got %q", gotAuth)
+	}
+	if resp.Accepted != 1 || resp.EventIDs[0] != "evt-1" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestHTTPTransport_SendBatchUsesBatchEndpoint(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		json.NewEncoder(w).Encode(BatchResponse{Status: "ok", Accepted: 2, EventIDs: []string{"evt-1", "evt-2"}})
+	}))
+	defer srv.Close()
+
+	tr := NewHTTPTransport(srv.URL, "", nil)
+	resp, err := tr.Send(context.Background(), []AuditEvent{{EventID: "evt-1"}, {EventID: "evt-2"}})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	if gotPath != "/api/v1/events/batch" {
+		t.Errorf("expected multi-event send to hit /api/v1/events/batch, got %q", gotPath)
+	}
+	if resp.Accepted != 2 {
+		t.Errorf("expected 2 events accepted, got %d", resp.Accepted)
+	}
+}
+
+func TestHTTPTransport_NonOKStatusReturnsCollectorError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte("slow down"))
+	}))
+	defer srv.Close()
+
+	tr := NewHTTPTransport(srv.URL, "", nil)
+	_, err := tr.Send(context.Background(), []AuditEvent{{EventID: "evt-1"}})
+	if err == nil {
+		t.Fatal("expected an error for a 429 response")
+	}
+
+	cerr, ok := err.(*CollectorError)
+	if !ok {
+		t.Fatalf("expected *CollectorError, got %T: %v", err, err)
+	}
+	if cerr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected status 429, got %d", cerr.StatusCode)
+	}
+}
@@ -1,12 +1,18 @@
 package audit
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"sort"
 	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
 )
 
-// Valid action types matching the Python ActionType enum.
-var validActionTypes = map[string]bool{
+// defaultActionTypes matches the Python ActionType enum.
+var defaultActionTypes = map[string]bool{
 	"READ":    true,
 	"WRITE":   true,
 	"UPDATE":  true,
@@ -23,8 +29,8 @@ var validActionTypes = map[string]bool{
 	"REJECT":  true,
 }
 
-// Valid action categories matching the Python ActionCategory enum.
-var validCategories = map[string]bool{
+// defaultCategories matches the Python ActionCategory enum.
+var defaultCategories = map[string]bool{
 	"DATABASE":   true,
 	"API":        true,
 	"AUTH":       true,
@@ -37,22 +43,237 @@ var validCategories = map[string]bool{
 	"ADMIN":      true,
 }
 
-// ValidateEvent checks that the event has valid action type, category,
-// and a non-empty operation. Returns nil if valid.
+// ValidationIssue is a single problem found in an event, pinned to the
+// field it came from.
+type ValidationIssue struct {
+	Field   string
+	Message string
+}
+
+// ValidationError aggregates every ValidationIssue found in one
+// Validator.Validate pass, rather than stopping at the first, so callers
+// can log or reject a batch with actionable diagnostics.
+type ValidationError struct {
+	Issues []ValidationIssue
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		parts[i] = fmt.Sprintf("%s: %s", issue.Field, issue.Message)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Validator checks AuditEvents against the built-in action type/category
+// taxonomy, any application-registered extensions, and optionally a JSON
+// Schema document.
+type Validator struct {
+	actionTypes map[string]bool
+	categories  map[string]bool
+	strict      bool
+	schema      *jsonschema.Schema
+	schemaErr   error
+}
+
+// ValidatorOption configures a Validator.
+type ValidatorOption func(*Validator)
+
+// WithAdditionalActionTypes extends the built-in action type taxonomy so
+// applications can use their own types without forking the SDK. Matching
+// is case-insensitive, as with the built-in types.
+func WithAdditionalActionTypes(types ...string) ValidatorOption {
+	return func(v *Validator) {
+		for _, t := range types {
+			v.actionTypes[strings.ToUpper(t)] = true
+		}
+	}
+}
+
+// WithAdditionalCategories extends the built-in category taxonomy.
+// Matching is case-insensitive, as with the built-in categories.
+func WithAdditionalCategories(categories ...string) ValidatorOption {
+	return func(v *Validator) {
+		for _, c := range categories {
+			v.categories[strings.ToUpper(c)] = true
+		}
+	}
+}
+
+// WithStrictMode makes the Validator reject any Custom/Metadata field
+// that the JSON Schema (see WithSchema) doesn't declare under
+// "properties", even if the schema's own additionalProperties would
+// allow it. If the schema does not declare "custom" or "metadata" at all,
+// that map's fields are not checked. Without WithSchema this option has
+// no effect.
+func WithStrictMode(strict bool) ValidatorOption {
+	return func(v *Validator) { v.strict = strict }
+}
+
+// WithSchema loads a draft-07 JSON Schema document (typically
+// schema/audit_event.schema.json) from r and enforces it on every event
+// in addition to the action type/category checks. A malformed schema is
+// not returned immediately; it surfaces as a ValidationError on the next
+// Validate call, matching the rest of the package's error handling.
+func WithSchema(r io.Reader) ValidatorOption {
+	return func(v *Validator) {
+		const resourceName = "audit_event.schema.json"
+		compiler := jsonschema.NewCompiler()
+		compiler.Draft = jsonschema.Draft7
+		if err := compiler.AddResource(resourceName, r); err != nil {
+			v.schemaErr = fmt.Errorf("load schema: %w", err)
+			return
+		}
+		schema, err := compiler.Compile(resourceName)
+		if err != nil {
+			v.schemaErr = fmt.Errorf("compile schema: %w", err)
+			return
+		}
+		v.schema = schema
+	}
+}
+
+// NewValidator builds a Validator seeded with the built-in action types
+// and categories, customized by opts.
+func NewValidator(opts ...ValidatorOption) *Validator {
+	v := &Validator{
+		actionTypes: copyBoolSet(defaultActionTypes),
+		categories:  copyBoolSet(defaultCategories),
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+func copyBoolSet(m map[string]bool) map[string]bool {
+	out := make(map[string]bool, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// defaultValidator backs the package-level ValidateEvent.
+var defaultValidator = NewValidator()
+
+// ValidateEvent checks that the event has a valid action type, category,
+// and a non-empty operation, using the package-level default Validator.
+// Applications that need custom types, categories, or JSON Schema
+// enforcement should build their own Validator with NewValidator and
+// attach it to a Client via WithValidator.
 func ValidateEvent(e *AuditEvent) error {
+	return defaultValidator.Validate(e)
+}
+
+// Validate checks e against the configured action types, categories, and
+// (if WithSchema was used) JSON Schema, aggregating every problem found
+// in one pass rather than stopping at the first.
+func (v *Validator) Validate(e *AuditEvent) error {
+	var issues []ValidationIssue
+
 	actionType := strings.ToUpper(e.Action.Type)
-	if !validActionTypes[actionType] {
-		return fmt.Errorf("invalid action type %q: must be one of READ, WRITE, UPDATE, DELETE, EXECUTE, CREATE, LOGIN, LOGOUT, ACCESS, MODIFY, GRANT, REVOKE, APPROVE, REJECT", e.Action.Type)
+	if !v.actionTypes[actionType] {
+		issues = append(issues, ValidationIssue{
+			Field:   "action.type",
+			Message: fmt.Sprintf("invalid action type %q: must be one of %s", e.Action.Type, joinSortedKeys(v.actionTypes)),
+		})
 	}
 
 	category := strings.ToUpper(e.Action.Category)
-	if !validCategories[category] {
-		return fmt.Errorf("invalid category %q: must be one of DATABASE, API, AUTH, FILE, SYSTEM, NETWORK, SECURITY, COMPLIANCE, USER, ADMIN", e.Action.Category)
+	if !v.categories[category] {
+		issues = append(issues, ValidationIssue{
+			Field:   "action.category",
+			Message: fmt.Sprintf("invalid category %q: must be one of %s", e.Action.Category, joinSortedKeys(v.categories)),
+		})
 	}
 
 	if strings.TrimSpace(e.Action.Operation) == "" {
-		return fmt.Errorf("action operation must not be empty")
+		issues = append(issues, ValidationIssue{Field: "action.operation", Message: "operation must not be empty"})
 	}
 
-	return nil
+	switch {
+	case v.schemaErr != nil:
+		issues = append(issues, ValidationIssue{Field: "$schema", Message: v.schemaErr.Error()})
+	case v.schema != nil:
+		issues = append(issues, v.validateSchema(e)...)
+		if v.strict {
+			issues = append(issues, v.strictPropertyIssues(e)...)
+		}
+	}
+
+	if len(issues) == 0 {
+		return nil
+	}
+	return &ValidationError{Issues: issues}
+}
+
+func (v *Validator) validateSchema(e *AuditEvent) []ValidationIssue {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return []ValidationIssue{{Field: "$schema", Message: fmt.Sprintf("marshal event: %v", err)}}
+	}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return []ValidationIssue{{Field: "$schema", Message: fmt.Sprintf("decode event: %v", err)}}
+	}
+
+	err = v.schema.Validate(doc)
+	if err == nil {
+		return nil
+	}
+
+	var verr *jsonschema.ValidationError
+	if !errors.As(err, &verr) {
+		return []ValidationIssue{{Field: "$schema", Message: err.Error()}}
+	}
+
+	var issues []ValidationIssue
+	collectSchemaIssues(verr, &issues)
+	return issues
+}
+
+func collectSchemaIssues(verr *jsonschema.ValidationError, out *[]ValidationIssue) {
+	if len(verr.Causes) == 0 {
+		*out = append(*out, ValidationIssue{Field: verr.InstanceLocation, Message: verr.Message})
+		return
+	}
+	for _, cause := range verr.Causes {
+		collectSchemaIssues(cause, out)
+	}
+}
+
+// strictPropertyIssues rejects any Custom/Metadata key that the schema's
+// "properties" didn't declare, regardless of the schema's own
+// additionalProperties setting.
+func (v *Validator) strictPropertyIssues(e *AuditEvent) []ValidationIssue {
+	var issues []ValidationIssue
+	issues = append(issues, undeclaredPropertyIssues("custom", v.schema.Properties["custom"], e.Custom)...)
+	issues = append(issues, undeclaredPropertyIssues("metadata", v.schema.Properties["metadata"], e.Metadata)...)
+	return issues
+}
+
+func undeclaredPropertyIssues(field string, sub *jsonschema.Schema, m map[string]interface{}) []ValidationIssue {
+	if sub == nil || len(m) == 0 {
+		return nil
+	}
+	var issues []ValidationIssue
+	for k := range m {
+		if _, ok := sub.Properties[k]; !ok {
+			issues = append(issues, ValidationIssue{
+				Field:   fmt.Sprintf("%s.%s", field, k),
+				Message: fmt.Sprintf("field %q is not declared in the schema (strict mode)", k),
+			})
+		}
+	}
+	return issues
+}
+
+func joinSortedKeys(m map[string]bool) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ", ")
 }
@@ -0,0 +1,98 @@
+package audit
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func validEventForSchema() *AuditEvent {
+	return &AuditEvent{
+		EventID:   "evt-1",
+		Timestamp: "2024-01-01T00:00:00Z",
+		Version:   "1.0.0",
+		Action: ActionContext{
+			Type:      "READ",
+			Category:  "DATABASE",
+			Operation: "query_users",
+		},
+	}
+}
+
+func TestWithSchema_ValidatesAgainstRealSchema(t *testing.T) {
+	f, err := os.Open("schema/audit_event.schema.json")
+	if err != nil {
+		t.Fatalf("open schema: %v", err)
+	}
+	defer f.Close()
+
+	v := NewValidator(WithSchema(f))
+
+	if err := v.Validate(validEventForSchema()); err != nil {
+		t.Fatalf("expected valid event to pass schema validation, got: %v", err)
+	}
+
+	bad := validEventForSchema()
+	bad.EventID = ""
+	err = v.Validate(bad)
+	if err == nil {
+		t.Fatal("expected empty event_id to fail schema validation")
+	}
+	if !strings.Contains(err.Error(), "$schema") && !strings.Contains(err.Error(), "event_id") {
+		t.Fatalf("expected schema error to reference the failing field, got: %v", err)
+	}
+}
+
+func TestWithSchema_MalformedSchemaSurfacesOnValidate(t *testing.T) {
+	v := NewValidator(WithSchema(strings.NewReader("{not valid json")))
+
+	err := v.Validate(validEventForSchema())
+	if err == nil {
+		t.Fatal("expected a malformed schema to fail validation")
+	}
+	if !strings.Contains(err.Error(), "$schema") {
+		t.Fatalf("expected the schema load error to surface under the $schema field, got: %v", err)
+	}
+}
+
+func TestWithStrictMode_RejectsUndeclaredCustomFields(t *testing.T) {
+	const schema = `{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"type": "object",
+		"properties": {
+			"custom": {
+				"type": "object",
+				"properties": {
+					"tenant_id": { "type": "string" }
+				}
+			}
+		}
+	}`
+
+	v := NewValidator(WithSchema(strings.NewReader(schema)), WithStrictMode(true))
+
+	e := validEventForSchema()
+	e.Custom = map[string]interface{}{"tenant_id": "acme"}
+	if err := v.Validate(e); err != nil {
+		t.Fatalf("expected declared custom field to pass in strict mode, got: %v", err)
+	}
+
+	e.Custom["undeclared_field"] = "whoops"
+	err := v.Validate(e)
+	if err == nil {
+		t.Fatal("expected an undeclared custom field to fail in strict mode")
+	}
+	if !strings.Contains(err.Error(), "undeclared_field") {
+		t.Fatalf("expected error to name the undeclared field, got: %v", err)
+	}
+}
+
+func TestWithStrictMode_NoEffectWithoutSchema(t *testing.T) {
+	v := NewValidator(WithStrictMode(true))
+
+	e := validEventForSchema()
+	e.Custom = map[string]interface{}{"anything": "goes"}
+	if err := v.Validate(e); err != nil {
+		t.Fatalf("expected WithStrictMode to be a no-op without WithSchema, got: %v", err)
+	}
+}
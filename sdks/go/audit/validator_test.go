@@ -0,0 +1,63 @@
+package audit
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidator_AdditionalActionTypesAndCategories(t *testing.T) {
+	v := NewValidator(
+		WithAdditionalActionTypes("SYNC"),
+		WithAdditionalCategories("BILLING"),
+	)
+
+	e := &AuditEvent{
+		Action: ActionContext{
+			Type:      "sync",
+			Category:  "billing",
+			Operation: "reconcile_invoices",
+		},
+	}
+	if err := v.Validate(e); err != nil {
+		t.Fatalf("expected custom type/category to be valid, got: %v", err)
+	}
+}
+
+func TestValidator_AdditionalTypesDoNotAffectDefaultValidator(t *testing.T) {
+	e := &AuditEvent{
+		Action: ActionContext{
+			Type:      "SYNC",
+			Category:  "DATABASE",
+			Operation: "test",
+		},
+	}
+	if err := ValidateEvent(e); err == nil {
+		t.Fatal("expected the package-level default validator to reject a type it was never extended with")
+	}
+}
+
+func TestValidator_AggregatesAllIssues(t *testing.T) {
+	v := NewValidator()
+	e := &AuditEvent{
+		Action: ActionContext{
+			Type:      "BOGUS",
+			Category:  "BOGUS",
+			Operation: "",
+		},
+	}
+
+	err := v.Validate(e)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	verr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("expected *ValidationError, got %T", err)
+	}
+	if len(verr.Issues) != 3 {
+		t.Fatalf("expected 3 aggregated issues (type, category, operation), got %d: %v", len(verr.Issues), verr.Issues)
+	}
+	if !strings.Contains(err.Error(), "invalid action type") || !strings.Contains(err.Error(), "invalid category") || !strings.Contains(err.Error(), "operation must not be empty") {
+		t.Fatalf("expected all three problems in the error message, got: %v", err)
+	}
+}